@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"net/url"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// rotatingFileScheme is the zap output path scheme registered by
+// ensureRotatingFileSink, e.g. "rotating-file:///var/chainlink/log/chainlink.log".
+const rotatingFileScheme = "rotating-file"
+
+// RotatingLogConfig holds the on-disk rotation tunables for the production
+// file sink. Field names match the node config options that populate them
+// (store.Config.LogFileMaxSizeMB et al.) so constructing one at the config
+// boundary is a straight field-for-field copy. A zero value
+// (LogFileMaxSizeMB == 0) disables rotation and falls back to a single
+// ever-growing log file.
+type RotatingLogConfig struct {
+	LogFileMaxSizeMB  int
+	LogFileMaxAgeDays int
+	LogFileMaxBackups int
+	LogFileCompress   bool
+}
+
+var (
+	rotatingSinkOnce sync.Once
+	rotatingSinkErr  error
+)
+
+// ensureRotatingFileSink registers the rotating-file:// zap sink, backed by
+// lumberjack.Logger, the first time it's called; later calls are no-ops.
+// zap only allows one registration per scheme per process, so the rotation
+// settings from the first caller (the node's own config) apply for the
+// lifetime of the process.
+func ensureRotatingFileSink(rotation RotatingLogConfig) error {
+	rotatingSinkOnce.Do(func() {
+		rotatingSinkErr = zap.RegisterSink(rotatingFileScheme, func(u *url.URL) (zap.Sink, error) {
+			return &lumberjackSink{Logger: &lumberjack.Logger{
+				Filename:   filepath.FromSlash(u.Path),
+				MaxSize:    rotation.LogFileMaxSizeMB,
+				MaxAge:     rotation.LogFileMaxAgeDays,
+				MaxBackups: rotation.LogFileMaxBackups,
+				Compress:   rotation.LogFileCompress,
+			}}, nil
+		})
+	})
+	return rotatingSinkErr
+}
+
+// lumberjackSink adapts *lumberjack.Logger (an io.WriteCloser) to zap.Sink,
+// which additionally requires Sync.
+type lumberjackSink struct {
+	*lumberjack.Logger
+}
+
+// Sync is a no-op: lumberjack writes straight through to the OS, so there's
+// nothing to flush.
+func (*lumberjackSink) Sync() error { return nil }
+
+// logDestinationURI returns the zap output path for on-disk logs: a plain,
+// ever-growing file when rotation is disabled (rotation.LogFileMaxSizeMB ==
+// 0), or the registered rotating-file sink otherwise.
+func logDestinationURI(dir string, rotation RotatingLogConfig) (string, error) {
+	if rotation.LogFileMaxSizeMB <= 0 {
+		return logFileURI(dir), nil
+	}
+	if err := ensureRotatingFileSink(rotation); err != nil {
+		return "", err
+	}
+	return rotatingFileScheme + "://" + filepath.ToSlash(filepath.Join(dir, "chainlink.log")), nil
+}
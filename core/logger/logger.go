@@ -3,9 +3,12 @@
 package logger
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"reflect"
 	"runtime"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -18,12 +21,15 @@ import (
 // It implements uber/zap's SugaredLogger interface and adds conditional logging helpers.
 type Logger struct {
 	*zap.SugaredLogger
+	zl          *zap.Logger
 	Orm         ORM
 	lvl         zapcore.Level
 	dir         string
 	jsonConsole bool
 	toDisk      bool
-	fields      []interface{}
+	rotation    RotatingLogConfig
+	fields      []interface{} // legacy key/value pairs accumulated via With/Named
+	typedFields []Field       // typed fields accumulated via WithFields
 }
 
 // Constants for service names for package specific logging configuration
@@ -41,6 +47,79 @@ func GetLogServices() []string {
 	}
 }
 
+// Field is a strongly-typed key/value pair for structured logging.
+// It is an alias of zapcore.Field so callers never need to import zap
+// directly to build one.
+type Field = zapcore.Field
+
+// String constructs a Field carrying a string value.
+func String(key, val string) Field {
+	return zap.String(key, val)
+}
+
+// Int32 constructs a Field carrying an int32 value.
+func Int32(key string, val int32) Field {
+	return zap.Int32(key, val)
+}
+
+// Int64 constructs a Field carrying an int64 value.
+func Int64(key string, val int64) Field {
+	return zap.Int64(key, val)
+}
+
+// Duration constructs a Field carrying a time.Duration value.
+func Duration(key string, val time.Duration) Field {
+	return zap.Duration(key, val)
+}
+
+// Err constructs a Field carrying an error under the conventional "error" key.
+func Err(err error) Field {
+	return zap.Error(err)
+}
+
+// Stringer constructs a Field from a fmt.Stringer, lazily calling String().
+func Stringer(key string, val fmt.Stringer) Field {
+	return zap.Stringer(key, val)
+}
+
+// Any constructs a Field by reflecting on the type of val. Prefer one of the
+// typed constructors above when the type is known.
+func Any(key string, val interface{}) Field {
+	return zap.Any(key, val)
+}
+
+type ctxFieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx that carries the given fields in
+// addition to any already stashed on it. Use this at request/job/pipeline-run
+// boundaries to attach correlation IDs that should appear on every log line
+// emitted while handling that request, e.g.:
+//
+//	ctx = logger.ContextWithFields(ctx, logger.String("jobID", jobID))
+//	...
+//	lggr.WithContext(ctx).Infow("starting run")
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	merged := copyFields(fieldsFromContext(ctx), fields...)
+	return context.WithValue(ctx, ctxFieldsKey{}, merged)
+}
+
+// fieldsFromContext returns the fields previously stashed on ctx via
+// ContextWithFields, or nil if there are none.
+func fieldsFromContext(ctx context.Context) []Field {
+	fields, _ := ctx.Value(ctxFieldsKey{}).([]Field)
+	return fields
+}
+
+// WithContext returns a new Logger with any fields stashed on ctx via
+// ContextWithFields (e.g. request, job, trace, or correlation IDs) attached.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	fields := fieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.WithFields(fields...)
+}
+
 // Write logs a message at the Info level and returns the length
 // of the given bytes.
 func (l *Logger) Write(b []byte) (int, error) {
@@ -48,27 +127,49 @@ func (l *Logger) Write(b []byte) (int, error) {
 	return len(b), nil
 }
 
-// With creates a new logger with the given arguments
+// With creates a new logger with the given arguments. Prefer WithFields for
+// new call sites: its typed Field constructors (String, Int64, Err, ...)
+// turn a mismatched key/value into a compile error.
 func (l *Logger) With(args ...interface{}) *Logger {
 	newLogger := *l
 	newLogger.SugaredLogger = l.SugaredLogger.With(args...)
-	newLogger.fields = copyFields(l.fields, args...)
+	newLogger.zl = newLogger.SugaredLogger.Desugar()
+	newLogger.fields = copyLegacyFields(l.fields, args...)
 	return &newLogger
 }
 
-// copyFields returns a copy of fields with add appended.
-func copyFields(fields []interface{}, add ...interface{}) []interface{} {
+// copyLegacyFields returns a copy of fields with add appended.
+func copyLegacyFields(fields []interface{}, add ...interface{}) []interface{} {
 	f := make([]interface{}, 0, len(fields)+len(add))
 	f = append(f, fields...)
 	f = append(f, add...)
 	return f
 }
 
+// WithFields creates a new logger with the given strongly-typed fields
+// attached.
+func (l *Logger) WithFields(fields ...Field) *Logger {
+	newLogger := *l
+	newLogger.zl = l.zl.With(fields...)
+	newLogger.SugaredLogger = newLogger.zl.Sugar()
+	newLogger.typedFields = copyFields(l.typedFields, fields...)
+	return &newLogger
+}
+
+// copyFields returns a copy of fields with add appended.
+func copyFields(fields []Field, add ...Field) []Field {
+	f := make([]Field, 0, len(fields)+len(add))
+	f = append(f, fields...)
+	f = append(f, add...)
+	return f
+}
+
 // Named creates a new named logger with the given name
 func (l *Logger) Named(name string) *Logger {
 	newLogger := *l
 	newLogger.SugaredLogger = l.SugaredLogger.Named(name).With("id", name)
-	newLogger.fields = copyFields(l.fields, "id", name)
+	newLogger.zl = newLogger.SugaredLogger.Desugar()
+	newLogger.fields = copyLegacyFields(l.fields, "id", name)
 	return &newLogger
 }
 
@@ -77,6 +178,36 @@ func (l *Logger) WithError(err error) *Logger {
 	return l.With("error", err)
 }
 
+// typed returns the underlying *zap.Logger as-is: the core's
+// zap.AddCallerSkip(1) was calibrated for SugaredLogger's own internal
+// indirection, which is exactly one frame — the same one frame DebugFields
+// and friends add on top of *zap.Logger, so no further adjustment is
+// needed. Adjusting here would double-count it and point caller at this
+// file instead of the real call site.
+func (l *Logger) typed() *zap.Logger {
+	return l.zl
+}
+
+// DebugFields logs msg at Debug level with the given strongly-typed fields.
+func (l *Logger) DebugFields(msg string, fields ...Field) {
+	l.typed().Debug(msg, fields...)
+}
+
+// InfoFields logs msg at Info level with the given strongly-typed fields.
+func (l *Logger) InfoFields(msg string, fields ...Field) {
+	l.typed().Info(msg, fields...)
+}
+
+// WarnFields logs msg at Warn level with the given strongly-typed fields.
+func (l *Logger) WarnFields(msg string, fields ...Field) {
+	l.typed().Warn(msg, fields...)
+}
+
+// ErrorFields logs msg at Error level with the given strongly-typed fields.
+func (l *Logger) ErrorFields(msg string, fields ...Field) {
+	l.typed().Error(msg, fields...)
+}
+
 // WarnIf logs the error if present.
 func (l *Logger) WarnIf(err error) {
 	if err != nil {
@@ -137,17 +268,38 @@ func (l *Logger) GetServiceLogLevels() (map[string]string, error) {
 func CreateLogger(zl *zap.SugaredLogger) *Logger {
 	return &Logger{
 		SugaredLogger: zl,
+		zl:            zl.Desugar(),
 	}
 }
 
+// ProductionLoggerOption configures optional behavior of CreateProductionLogger
+// and NewProductionConfig. Adding new knobs this way keeps existing call
+// sites compiling unchanged.
+type ProductionLoggerOption func(*productionLoggerOptions)
+
+type productionLoggerOptions struct {
+	rotation RotatingLogConfig
+}
+
+// WithFileRotation rotates and optionally gzip-compresses the on-disk log
+// per rotation (see RotatingLogConfig) instead of letting it grow
+// unbounded. Sourced from the node's LogFileMaxSizeMB, LogFileMaxAgeDays,
+// LogFileMaxBackups, and LogFileCompress config options.
+func WithFileRotation(rotation RotatingLogConfig) ProductionLoggerOption {
+	return func(o *productionLoggerOptions) { o.rotation = rotation }
+}
+
 // initLogConfig builds a zap.Config for a logger
-func initLogConfig(dir string, jsonConsole bool, lvl zapcore.Level, toDisk bool) zap.Config {
+func initLogConfig(dir string, jsonConsole bool, lvl zapcore.Level, toDisk bool, rotation RotatingLogConfig) zap.Config {
 	config := zap.NewProductionConfig()
 	if !jsonConsole {
 		config.OutputPaths = []string{"pretty://console"}
 	}
 	if toDisk {
-		destination := logFileURI(dir)
+		destination, err := logDestinationURI(dir, rotation)
+		if err != nil {
+			log.Fatal(err)
+		}
 		config.OutputPaths = append(config.OutputPaths, destination)
 		config.ErrorOutputPaths = append(config.ErrorOutputPaths, destination)
 	}
@@ -156,10 +308,17 @@ func initLogConfig(dir string, jsonConsole bool, lvl zapcore.Level, toDisk bool)
 }
 
 // CreateProductionLogger returns a log config for the passed directory
-// with the given LogLevel and customizes stdout for pretty printing.
+// with the given LogLevel and customizes stdout for pretty printing. Pass
+// WithFileRotation to rotate/compress the on-disk log instead of letting it
+// grow unbounded.
 func CreateProductionLogger(
-	dir string, jsonConsole bool, lvl zapcore.Level, toDisk bool) *Logger {
-	config := initLogConfig(dir, jsonConsole, lvl, toDisk)
+	dir string, jsonConsole bool, lvl zapcore.Level, toDisk bool, opts ...ProductionLoggerOption) *Logger {
+	var o productionLoggerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	config := initLogConfig(dir, jsonConsole, lvl, toDisk, o.rotation)
 
 	zl, err := config.Build(zap.AddCallerSkip(1))
 	if err != nil {
@@ -167,30 +326,42 @@ func CreateProductionLogger(
 	}
 	return &Logger{
 		SugaredLogger: zl.Sugar(),
+		zl:            zl,
 		lvl:           lvl,
 		dir:           dir,
 		jsonConsole:   jsonConsole,
 		toDisk:        toDisk,
+		rotation:      o.rotation,
 	}
 }
 
-// InitServiceLevelLogger builds a service level logger with a given logging level & serviceName
+// InitServiceLevelLogger builds a service level logger with a given logging level & serviceName.
+// The returned logger's level is backed by a registered zap.AtomicLevel (see
+// RegisterServiceLevel), so a later call to SetServiceLevel for the same
+// serviceName takes effect immediately without rebuilding this core.
 func (l *Logger) InitServiceLevelLogger(serviceName string, logLevel string) (*Logger, error) {
 	var ll zapcore.Level
 	if err := ll.UnmarshalText([]byte(logLevel)); err != nil {
 		return nil, err
 	}
+	al := RegisterServiceLevel(serviceName, ll)
 
-	config := initLogConfig(l.dir, l.jsonConsole, ll, l.toDisk)
+	config := initLogConfig(l.dir, l.jsonConsole, ll, l.toDisk, l.rotation)
+	config.Level = *al
 
 	zl, err := config.Build(zap.AddCallerSkip(1))
 	if err != nil {
 		return nil, err
 	}
 
+	named := zl.Named(serviceName).With(l.typedFields...)
+	sugared := named.Sugar().With(l.fields...)
+
 	newLogger := *l
-	newLogger.SugaredLogger = zl.Named(serviceName).Sugar().With(l.fields...)
-	newLogger.fields = copyFields(l.fields)
+	newLogger.SugaredLogger = sugared
+	newLogger.zl = sugared.Desugar()
+	newLogger.fields = copyLegacyFields(l.fields)
+	newLogger.typedFields = copyFields(l.typedFields)
 	return &newLogger, nil
 }
 
@@ -207,8 +378,15 @@ func (l *Logger) ServiceLogLevel(serviceName string) (string, error) {
 	return l.lvl.String(), nil
 }
 
-// NewProductionConfig returns a production logging config
-func NewProductionConfig(lvl zapcore.Level, dir string, jsonConsole, toDisk bool) (c zap.Config) {
+// NewProductionConfig returns a production logging config. Pass
+// WithFileRotation to rotate/compress the on-disk log instead of letting it
+// grow unbounded.
+func NewProductionConfig(lvl zapcore.Level, dir string, jsonConsole, toDisk bool, opts ...ProductionLoggerOption) (c zap.Config) {
+	var o productionLoggerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var outputPath string
 	if jsonConsole {
 		outputPath = "stderr"
@@ -226,7 +404,10 @@ func NewProductionConfig(lvl zapcore.Level, dir string, jsonConsole, toDisk bool
 		ErrorOutputPaths: []string{"stderr"},
 	}
 	if toDisk {
-		destination := logFileURI(dir)
+		destination, err := logDestinationURI(dir, o.rotation)
+		if err != nil {
+			log.Fatal(err)
+		}
 		c.OutputPaths = append(c.OutputPaths, destination)
 		c.ErrorOutputPaths = append(c.ErrorOutputPaths, destination)
 	}
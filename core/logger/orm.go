@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"encoding/json"
+
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// ORM is the persistence layer backing per-service log levels: the level a
+// service should log at survives node restarts in the service_log_levels
+// table, and ListenForServiceLogLevelChanges lets every node in a cluster
+// converge on a level set by any one of them.
+type ORM interface {
+	GetServiceLogLevel(serviceName string) (string, error)
+	SetServiceLogLevel(serviceName string, level string) error
+	ListenForServiceLogLevelChanges() (postgres.Subscription, error)
+}
+
+type orm struct {
+	db *gorm.DB
+}
+
+// NewORM returns an ORM backed by db.
+func NewORM(db *gorm.DB) ORM {
+	return &orm{db: db}
+}
+
+// GetServiceLogLevel retrieves the log level persisted for serviceName. It
+// returns gorm.ErrRecordNotFound if none is persisted.
+func (o *orm) GetServiceLogLevel(serviceName string) (string, error) {
+	var row struct {
+		ServiceLogLevel string
+	}
+	res := o.db.Table("service_log_levels").
+		Select("service_log_level").
+		Where("service_name = ?", serviceName).
+		Scan(&row)
+	if res.Error != nil {
+		return "", res.Error
+	}
+	if res.RowsAffected == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return row.ServiceLogLevel, nil
+}
+
+// SetServiceLogLevel upserts the log level persisted for serviceName and
+// issues a NOTIFY on the service_log_levels channel so other nodes in the
+// cluster converge on it.
+func (o *orm) SetServiceLogLevel(serviceName string, level string) error {
+	return o.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO service_log_levels (service_name, service_log_level, updated_at)
+			VALUES (?, ?, now())
+			ON CONFLICT (service_name)
+			DO UPDATE SET service_log_level = excluded.service_log_level, updated_at = excluded.updated_at
+		`, serviceName, level).Error
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(serviceLogLevelNotification{Service: serviceName, Level: level})
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`SELECT pg_notify('service_log_levels', ?)`, string(payload)).Error
+	})
+}
+
+// ListenForServiceLogLevelChanges subscribes to the service_log_levels
+// NOTIFY channel, mirroring job.ORM's ListenForNewJobs/ListenForDeletedJobs.
+func (o *orm) ListenForServiceLogLevelChanges() (postgres.Subscription, error) {
+	return postgres.NewSubscription(o.db, "service_log_levels")
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelRegistry holds a zap.AtomicLevel per registered service, so a
+// service's level can be flipped at runtime without rebuilding its
+// underlying zap core.
+type levelRegistry struct {
+	mu     sync.RWMutex
+	levels map[string]*zap.AtomicLevel
+}
+
+var registry = &levelRegistry{levels: make(map[string]*zap.AtomicLevel)}
+
+// RegisterServiceLevel registers service with the given initial level and
+// returns the zap.AtomicLevel backing it. If service is already registered,
+// its existing AtomicLevel is returned unchanged. InitServiceLevelLogger
+// calls this for HeadTracker, FluxMonitor, and Keeper, but any service name
+// may be registered this way.
+func RegisterServiceLevel(service string, lvl zapcore.Level) *zap.AtomicLevel {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if al, ok := registry.levels[service]; ok {
+		return al
+	}
+	al := zap.NewAtomicLevelAt(lvl)
+	registry.levels[service] = &al
+	return &al
+}
+
+// SetServiceLevel atomically updates the logging level of a previously
+// registered service without rebuilding its zap core, persists the change
+// via the ORM, and returns an error if service was never registered.
+func (l *Logger) SetServiceLevel(service string, lvl zapcore.Level) error {
+	registry.mu.RLock()
+	al, ok := registry.levels[service]
+	registry.mu.RUnlock()
+	if !ok {
+		return errors.Errorf("logger: service %q is not registered", service)
+	}
+	al.SetLevel(lvl)
+	if l.Orm != nil {
+		if err := l.Orm.SetServiceLogLevel(service, lvl.String()); err != nil {
+			return errors.Wrap(err, "failed to persist service log level")
+		}
+	}
+	return nil
+}
+
+// serviceLogLevelNotification is the JSON payload sent over the
+// service_log_levels LISTEN/NOTIFY channel.
+type serviceLogLevelNotification struct {
+	Service string `json:"service"`
+	Level   string `json:"level"`
+}
+
+// ListenForServiceLogLevelChanges subscribes to Postgres NOTIFY events on
+// the service_log_levels table and applies incoming changes to the local
+// AtomicLevel registry, so every node in a cluster converges on the same
+// per-service levels shortly after any one of them calls SetServiceLevel.
+// The returned stop function unsubscribes and stops the background
+// goroutine; callers must invoke it during shutdown.
+func (l *Logger) ListenForServiceLogLevelChanges() (stop func(), err error) {
+	if l.Orm == nil {
+		return nil, errors.New("logger: no ORM configured")
+	}
+	sub, err := l.Orm.ListenForServiceLogLevelChanges()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to subscribe to service log level changes")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer sub.Close()
+		for {
+			select {
+			case <-done:
+				return
+			case payload, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+				l.applyServiceLogLevelNotification(payload)
+			}
+		}
+	}()
+
+	var stopOnce sync.Once
+	return func() { stopOnce.Do(func() { close(done) }) }, nil
+}
+
+// applyServiceLogLevelNotification parses a single NOTIFY payload and, if
+// valid and for a registered service, applies it to that service's
+// AtomicLevel.
+func (l *Logger) applyServiceLogLevelNotification(payload []byte) {
+	var notification serviceLogLevelNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		l.ErrorFields("received malformed service_log_levels notification", Err(err))
+		return
+	}
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(notification.Level)); err != nil {
+		l.ErrorFields("received invalid level in service_log_levels notification", String("service", notification.Service), Err(err))
+		return
+	}
+	registry.mu.RLock()
+	al, ok := registry.levels[notification.Service]
+	registry.mu.RUnlock()
+	if ok {
+		al.SetLevel(lvl)
+	}
+}
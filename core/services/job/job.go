@@ -0,0 +1,13 @@
+package job
+
+import "time"
+
+// Job is a persisted job spec: the configuration that tells the node what
+// to run (its pipeline) and when. It is the unit ORM's CRUD and claiming
+// methods operate on.
+type Job struct {
+	ID        int32 `gorm:"primarykey"`
+	Name      string
+	ClaimedBy *string
+	CreatedAt time.Time
+}
@@ -192,6 +192,34 @@ func (_m *ORM) FindJobTx(id int32) (job.Job, error) {
 	return r0, r1
 }
 
+// IteratePipelineRuns provides a mock function with given fields: ctx, fn
+func (_m *ORM) IteratePipelineRuns(ctx context.Context, fn func(pipeline.Run) error) error {
+	ret := _m.Called(ctx, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(pipeline.Run) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IteratePipelineRunsByJobID provides a mock function with given fields: ctx, jobID, fn
+func (_m *ORM) IteratePipelineRunsByJobID(ctx context.Context, jobID int32, fn func(pipeline.Run) error) error {
+	ret := _m.Called(ctx, jobID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, func(pipeline.Run) error) error); ok {
+		r0 = rf(ctx, jobID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // JobsV2 provides a mock function with given fields: offset, limit
 func (_m *ORM) JobsV2(offset int, limit int) ([]job.Job, int, error) {
 	ret := _m.Called(offset, limit)
@@ -298,6 +326,36 @@ func (_m *ORM) PipelineRuns(offset int, size int) ([]pipeline.Run, int, error) {
 	return r0, r1, r2
 }
 
+// PipelineRunsAfter provides a mock function with given fields: cursor, limit
+func (_m *ORM) PipelineRunsAfter(cursor job.RunCursor, limit int) ([]pipeline.Run, job.RunCursor, error) {
+	ret := _m.Called(cursor, limit)
+
+	var r0 []pipeline.Run
+	if rf, ok := ret.Get(0).(func(job.RunCursor, int) []pipeline.Run); ok {
+		r0 = rf(cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.Run)
+		}
+	}
+
+	var r1 job.RunCursor
+	if rf, ok := ret.Get(1).(func(job.RunCursor, int) job.RunCursor); ok {
+		r1 = rf(cursor, limit)
+	} else {
+		r1 = ret.Get(1).(job.RunCursor)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(job.RunCursor, int) error); ok {
+		r2 = rf(cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // PipelineRunsByJobID provides a mock function with given fields: jobID, offset, size
 func (_m *ORM) PipelineRunsByJobID(jobID int32, offset int, size int) ([]pipeline.Run, int, error) {
 	ret := _m.Called(jobID, offset, size)
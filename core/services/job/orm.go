@@ -0,0 +1,254 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// ORM is the persistence layer for jobs and their pipeline runs.
+type ORM interface {
+	Close() error
+
+	CreateJob(ctx context.Context, jobSpec *Job, p pipeline.Pipeline) (Job, error)
+	DeleteJob(ctx context.Context, id int32) error
+	FindJob(ctx context.Context, id int32) (Job, error)
+	FindJobTx(id int32) (Job, error)
+	FindJobIDsWithBridge(name string) ([]int32, error)
+	JobsV2(offset, limit int) ([]Job, int, error)
+
+	ClaimUnclaimedJobs(ctx context.Context) ([]Job, error)
+	UnclaimJob(ctx context.Context, id int32) error
+	CheckForDeletedJobs(ctx context.Context) ([]int32, error)
+	ListenForNewJobs() (postgres.Subscription, error)
+	ListenForDeletedJobs() (postgres.Subscription, error)
+
+	RecordError(ctx context.Context, jobID int32, description string)
+	DismissError(ctx context.Context, errorID int32) error
+
+	PipelineRuns(offset, size int) ([]pipeline.Run, int, error)
+	PipelineRunsByJobID(jobID int32, offset, size int) ([]pipeline.Run, int, error)
+
+	// IteratePipelineRuns streams every pipeline run to fn, oldest first,
+	// without materializing them all in memory at once: it opens a
+	// read-only transaction and DECLAREs a server-side CURSOR over the
+	// pipeline_runs table, fetching and yielding one batch at a time until
+	// fn returns an error or the cursor is exhausted. fn's error (if any) is
+	// returned to the caller and rolls the transaction back; otherwise the
+	// transaction is committed. Either way it writes nothing: it exists only
+	// to hold the cursor open.
+	IteratePipelineRuns(ctx context.Context, fn func(pipeline.Run) error) error
+
+	// IteratePipelineRunsByJobID is IteratePipelineRuns restricted to a
+	// single job's runs.
+	IteratePipelineRunsByJobID(ctx context.Context, jobID int32, fn func(pipeline.Run) error) error
+
+	// PipelineRunsAfter returns up to limit pipeline runs strictly after
+	// cursor in descending (created_at, id) order, newest first, along with
+	// the cursor to pass to the next call. Unlike PipelineRuns' offset pagination, a page here
+	// costs the same regardless of how deep into the result set it is, and
+	// rows inserted or deleted ahead of the cursor can't shift later pages
+	// or duplicate/skip rows the caller already saw. The zero RunCursor
+	// starts from the beginning.
+	PipelineRunsAfter(cursor RunCursor, limit int) ([]pipeline.Run, RunCursor, error)
+}
+
+const pipelineRunCursorBatchSize = 256
+
+type orm struct {
+	db *gorm.DB
+}
+
+// NewORM returns an ORM backed by db.
+func NewORM(db *gorm.DB) ORM {
+	return &orm{db: db}
+}
+
+func (o *orm) Close() error {
+	sqlDB, err := o.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (o *orm) CreateJob(ctx context.Context, jobSpec *Job, p pipeline.Pipeline) (Job, error) {
+	err := o.db.WithContext(ctx).Create(jobSpec).Error
+	if err != nil {
+		return Job{}, errors.Wrap(err, "failed to create job")
+	}
+	return *jobSpec, nil
+}
+
+func (o *orm) DeleteJob(ctx context.Context, id int32) error {
+	return o.db.WithContext(ctx).Where("id = ?", id).Delete(&Job{}).Error
+}
+
+func (o *orm) FindJob(ctx context.Context, id int32) (Job, error) {
+	var j Job
+	err := o.db.WithContext(ctx).First(&j, "id = ?", id).Error
+	return j, err
+}
+
+func (o *orm) FindJobTx(id int32) (Job, error) {
+	return o.FindJob(context.Background(), id)
+}
+
+func (o *orm) FindJobIDsWithBridge(name string) ([]int32, error) {
+	var ids []int32
+	err := o.db.Table("jobs").
+		Where("bridge_name = ?", name).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (o *orm) JobsV2(offset, limit int) ([]Job, int, error) {
+	var jobs []Job
+	var count int64
+	if err := o.db.Model(&Job{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	err := o.db.Order("id ASC").Offset(offset).Limit(limit).Find(&jobs).Error
+	return jobs, int(count), err
+}
+
+func (o *orm) ClaimUnclaimedJobs(ctx context.Context) ([]Job, error) {
+	var jobs []Job
+	err := o.db.WithContext(ctx).Where("claimed_by IS NULL").Find(&jobs).Error
+	return jobs, err
+}
+
+func (o *orm) UnclaimJob(ctx context.Context, id int32) error {
+	return o.db.WithContext(ctx).Model(&Job{}).Where("id = ?", id).Update("claimed_by", nil).Error
+}
+
+func (o *orm) CheckForDeletedJobs(ctx context.Context) ([]int32, error) {
+	var ids []int32
+	err := o.db.WithContext(ctx).Table("jobs").
+		Where("deleted_at IS NOT NULL").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (o *orm) ListenForNewJobs() (postgres.Subscription, error) {
+	return postgres.NewSubscription(o.db, "new_jobs")
+}
+
+func (o *orm) ListenForDeletedJobs() (postgres.Subscription, error) {
+	return postgres.NewSubscription(o.db, "deleted_jobs")
+}
+
+func (o *orm) RecordError(ctx context.Context, jobID int32, description string) {
+	o.db.WithContext(ctx).Exec(`
+		INSERT INTO job_spec_errors (job_id, description, occurrences, created_at, updated_at)
+		VALUES (?, ?, 1, now(), now())
+		ON CONFLICT (job_id, description)
+		DO UPDATE SET occurrences = job_spec_errors.occurrences + 1, updated_at = excluded.updated_at
+	`, jobID, description)
+}
+
+func (o *orm) DismissError(ctx context.Context, errorID int32) error {
+	return o.db.WithContext(ctx).Exec("DELETE FROM job_spec_errors WHERE id = ?", errorID).Error
+}
+
+func (o *orm) PipelineRuns(offset, size int) ([]pipeline.Run, int, error) {
+	var runs []pipeline.Run
+	var count int64
+	if err := o.db.Table("pipeline_runs").Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	err := o.db.Table("pipeline_runs").Order("created_at DESC, id DESC").Offset(offset).Limit(size).Find(&runs).Error
+	return runs, int(count), err
+}
+
+func (o *orm) PipelineRunsByJobID(jobID int32, offset, size int) ([]pipeline.Run, int, error) {
+	var runs []pipeline.Run
+	var count int64
+	q := o.db.Table("pipeline_runs").Where("pipeline_spec_id IN (SELECT id FROM pipeline_specs WHERE job_id = ?)", jobID)
+	if err := q.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	err := q.Order("created_at DESC, id DESC").Offset(offset).Limit(size).Find(&runs).Error
+	return runs, int(count), err
+}
+
+func (o *orm) IteratePipelineRuns(ctx context.Context, fn func(pipeline.Run) error) error {
+	return o.iteratePipelineRunsCursor(ctx, "SELECT * FROM pipeline_runs ORDER BY created_at, id", nil, fn)
+}
+
+func (o *orm) IteratePipelineRunsByJobID(ctx context.Context, jobID int32, fn func(pipeline.Run) error) error {
+	const q = `
+		SELECT pr.* FROM pipeline_runs pr
+		JOIN pipeline_specs ps ON ps.id = pr.pipeline_spec_id
+		WHERE ps.job_id = ?
+		ORDER BY pr.created_at, pr.id
+	`
+	return o.iteratePipelineRunsCursor(ctx, q, []interface{}{jobID}, fn)
+}
+
+// iteratePipelineRunsCursor streams the rows of query through fn
+// pipelineRunCursorBatchSize at a time, using a server-side cursor held
+// open by a read-only transaction so the full result set never has to fit
+// in memory at once. The transaction is rolled back if a fetch or fn fails
+// and committed otherwise; it never writes anything, it only exists to
+// scope the cursor's lifetime.
+func (o *orm) iteratePipelineRunsCursor(ctx context.Context, query string, args []interface{}, fn func(pipeline.Run) error) error {
+	return o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DECLARE pipeline_runs_cursor NO SCROLL CURSOR FOR "+query, args...).Error; err != nil {
+			return errors.Wrap(err, "failed to declare pipeline runs cursor")
+		}
+		defer tx.Exec("CLOSE pipeline_runs_cursor")
+
+		fetch := fmt.Sprintf("FETCH FORWARD %d FROM pipeline_runs_cursor", pipelineRunCursorBatchSize)
+		for {
+			var batch []pipeline.Run
+			err := tx.Raw(fetch).Scan(&batch).Error
+			if err != nil {
+				return errors.Wrap(err, "failed to fetch from pipeline runs cursor")
+			}
+			for _, run := range batch {
+				if err := fn(run); err != nil {
+					return err
+				}
+			}
+			if len(batch) < pipelineRunCursorBatchSize {
+				return nil
+			}
+		}
+	}, &sql.TxOptions{ReadOnly: true})
+}
+
+// PipelineRunsAfter implements keyset pagination over pipeline_runs, newest
+// first by (created_at, id) descending — the same order PipelineRuns'
+// offset pagination uses, so a caller switching between the two on the same
+// GET /v2/pipeline/runs endpoint sees a consistent ordering rather than a
+// silent reversal. Rather than an offset, the WHERE clause excludes
+// everything at or after cursor directly, so the query does the same
+// amount of work for page 1 and page 10000, and rows inserted or deleted
+// ahead of the cursor can't shift or duplicate pages the caller already
+// fetched. The returned cursor is the last row's (created_at, id); callers
+// pass it back verbatim (via RunCursor.Encode/job.DecodeRunCursor) to fetch
+// the next page. An empty result returns the cursor unchanged.
+func (o *orm) PipelineRunsAfter(cursor RunCursor, limit int) ([]pipeline.Run, RunCursor, error) {
+	q := o.db.Table("pipeline_runs").Order("created_at DESC, id DESC").Limit(limit)
+	if !cursor.CreatedAt.IsZero() || cursor.ID != 0 {
+		q = q.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	var runs []pipeline.Run
+	if err := q.Find(&runs).Error; err != nil {
+		return nil, cursor, err
+	}
+	if len(runs) == 0 {
+		return runs, cursor, nil
+	}
+
+	last := runs[len(runs)-1]
+	return runs, RunCursor{ID: last.ID, CreatedAt: last.CreatedAt}, nil
+}
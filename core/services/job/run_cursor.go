@@ -0,0 +1,41 @@
+package job
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RunCursor is an opaque keyset-pagination cursor into pipeline runs,
+// returned by ORM.PipelineRunsAfter. Callers should treat its contents as
+// opaque: encode it to a token with Encode, and pass the token straight
+// back through DecodeRunCursor to fetch the next page.
+type RunCursor struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+// Encode serializes the cursor into the opaque token returned to API
+// callers, e.g. in an `after=` query parameter.
+func (c RunCursor) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode run cursor")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeRunCursor parses a token produced by RunCursor.Encode.
+func DecodeRunCursor(token string) (RunCursor, error) {
+	var cursor RunCursor
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, errors.Wrap(err, "failed to decode run cursor")
+	}
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return cursor, errors.Wrap(err, "failed to unmarshal run cursor")
+	}
+	return cursor, nil
+}
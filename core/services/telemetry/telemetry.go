@@ -1,18 +1,251 @@
 package telemetry
 
-import "github.com/smartcontractkit/chainlink/core/services/synchronization"
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/synchronization"
+)
+
+var promTelemetryDropped = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "telemetry_dropped_total",
+	Help: "Number of telemetry log entries dropped because the in-memory buffer overflowed",
+})
+
+const (
+	defaultBufferCapacity = 4096
+	defaultMaxBatchCount  = 100
+	defaultFlushInterval  = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// Clock abstracts time so the flush cadence can be driven deterministically
+// in tests.
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// AgentConfig tunes the batching and backoff behavior of Agent. Any
+// zero-valued field falls back to a sensible default.
+type AgentConfig struct {
+	BufferCapacity int           // max buffered entries before the oldest are dropped
+	MaxBatchCount  int           // max entries sent per flush
+	FlushInterval  time.Duration // flush cadence while the buffer isn't overflowing
+	MaxBackoff     time.Duration // flush cadence ceiling once it is
+	Clock          Clock
+}
+
+func (c *AgentConfig) setDefaults() {
+	if c.BufferCapacity <= 0 {
+		c.BufferCapacity = defaultBufferCapacity
+	}
+	if c.MaxBatchCount <= 0 {
+		c.MaxBatchCount = defaultMaxBatchCount
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = defaultFlushInterval
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaultMaxBackoff
+	}
+	if c.Clock == nil {
+		c.Clock = realClock{}
+	}
+}
+
+// sender is the subset of synchronization.WebSocketClient that Agent
+// depends on. Send is fire-and-forget and reports nothing about whether the
+// frame reached the explorer, so Agent has no per-send error to back off
+// on; see Agent's doc comment for how it copes with that.
+type sender interface {
+	Send(data []byte)
+}
+
+// Agent buffers telemetry logs in a bounded in-memory ring and flushes them
+// to the explorer over wsclient from a background goroutine, sending
+// buffered entries individually (preserving the explorer's existing
+// one-message-per-log wire format) but batched into a single flush pass per
+// wake-up. Because wsclient.Send can't report a per-send failure, Agent
+// can't back off on a send error directly; instead it treats the buffer
+// having overflowed since the last flush as its signal that the socket
+// isn't keeping up, and backs the flush cadence off exponentially (with
+// jitter) until a flush completes without overflow. If the buffer fills up
+// before the flusher catches up, the oldest entries are dropped and
+// telemetry_dropped_total is incremented rather than blocking callers.
 type Agent struct {
-	wsclient synchronization.WebSocketClient
+	wsclient sender
+	cfg      AgentConfig
+
+	mu         sync.Mutex
+	buf        [][]byte
+	overflowed bool
+
+	flushMu sync.Mutex // serializes flushOnce between runLoop and SendLogSync
+
+	flushC chan struct{}
+	closed chan struct{}
+	done   chan struct{}
 }
 
-// NewAgent returns a Agent which is just a thin wrapper over
-// the wsclient for now
+// NewAgent returns an Agent which batches writes to wsclient using default
+// tuning (see AgentConfig).
 func NewAgent(wsclient synchronization.WebSocketClient) *Agent {
-	return &Agent{wsclient}
+	return NewAgentWithConfig(wsclient, AgentConfig{})
+}
+
+// NewAgentWithConfig returns an Agent configured per cfg.
+func NewAgentWithConfig(wsclient synchronization.WebSocketClient, cfg AgentConfig) *Agent {
+	cfg.setDefaults()
+	return &Agent{
+		wsclient: wsclient,
+		cfg:      cfg,
+		flushC:   make(chan struct{}, 1),
+		closed:   make(chan struct{}),
+	}
+}
+
+// Start launches the background flusher goroutine. It returns immediately;
+// call Close to stop the goroutine and release resources.
+func (t *Agent) Start(ctx context.Context) error {
+	t.done = make(chan struct{})
+	go t.runLoop(ctx)
+	return nil
 }
 
-// SendLog sends a telemetry log to the explorer
+// Close stops the background flusher and waits for it to exit. It is safe
+// to call even if Start was never called, and safe to call more than once.
+func (t *Agent) Close() error {
+	select {
+	case <-t.closed:
+	default:
+		close(t.closed)
+	}
+	if t.done != nil {
+		<-t.done
+	}
+	return nil
+}
+
+// SendLog enqueues log for asynchronous, batched delivery. If the buffer is
+// already at BufferCapacity, the oldest buffered entry is dropped to make
+// room and telemetry_dropped_total is incremented.
 func (t *Agent) SendLog(log []byte) {
-	t.wsclient.Send(log)
+	t.enqueue(log)
+}
+
+// SendLogSync enqueues log and immediately flushes up to MaxBatchCount of
+// the oldest buffered entries on the calling goroutine, so log itself is
+// sent only if no more than MaxBatchCount entries are buffered ahead of
+// and including it. It exists for tests that need logs to reach wsclient
+// without racing the background flusher; since wsclient.Send can't report
+// failure, this confirms the send was attempted, not that it was delivered.
+func (t *Agent) SendLogSync(log []byte) {
+	t.enqueue(log)
+	t.flushOnce()
+}
+
+func (t *Agent) enqueue(log []byte) {
+	entry := make([]byte, len(log))
+	copy(entry, log)
+
+	t.mu.Lock()
+	if len(t.buf) >= t.cfg.BufferCapacity {
+		t.buf = t.buf[1:]
+		t.overflowed = true
+		promTelemetryDropped.Inc()
+	}
+	t.buf = append(t.buf, entry)
+	t.mu.Unlock()
+
+	select {
+	case t.flushC <- struct{}{}:
+	default:
+	}
+}
+
+func (t *Agent) runLoop(ctx context.Context) {
+	defer close(t.done)
+
+	backoff := t.cfg.FlushInterval
+	for {
+		// While backing off, new-entry wake-ups are ignored so the next
+		// flush waits out the backoff delay.
+		flushC := t.flushC
+		if backoff > t.cfg.FlushInterval {
+			flushC = nil
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.closed:
+			return
+		case <-flushC:
+		case <-t.cfg.Clock.After(jitter(backoff)):
+		}
+
+		if t.flushOnce() {
+			backoff *= 2
+			if backoff > t.cfg.MaxBackoff {
+				backoff = t.cfg.MaxBackoff
+			}
+			logger.Default.WarnFields("telemetry: buffer overflowed since last flush, backing off", logger.Duration("nextFlush", backoff))
+		} else {
+			backoff = t.cfg.FlushInterval
+		}
+	}
+}
+
+// jitter returns a random duration in [d/2, d), so concurrent agents
+// backing off together don't all retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d)/2))
+}
+
+// flushOnce drains up to MaxBatchCount of the oldest buffered entries and
+// sends them individually to wsclient, returning whether the buffer had overflowed
+// (i.e. dropped entries) since the last flush.
+func (t *Agent) flushOnce() (overflowed bool) {
+	t.flushMu.Lock()
+	defer t.flushMu.Unlock()
+
+	entries, overflowed := t.drain()
+	for _, entry := range entries {
+		t.wsclient.Send(entry)
+	}
+	return overflowed
+}
+
+// drain atomically removes and returns up to MaxBatchCount buffered
+// entries, along with whether the buffer has overflowed since the last
+// drain. The read and the trim happen under a single lock acquisition, so a
+// concurrent enqueue's drop-oldest can't invalidate the entries this flush
+// is about to remove.
+func (t *Agent) drain() ([][]byte, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := len(t.buf)
+	if n > t.cfg.MaxBatchCount {
+		n = t.cfg.MaxBatchCount
+	}
+	entries := make([][]byte, n)
+	copy(entries, t.buf[:n])
+	t.buf = t.buf[n:]
+
+	overflowed := t.overflowed
+	t.overflowed = false
+	return entries, overflowed
 }
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultJobLogTail is how many matching lines JobLogController.Show returns
+// when the caller doesn't pass a limit.
+const defaultJobLogTail = 1000
+
+// JobLogController streams the most recent log lines emitted while running
+// a single job, filtered by the "jobID" field the structured logger attaches
+// via logger.ContextWithFields. It is mounted on the authenticated /v2
+// route group alongside ServiceLogController.
+type JobLogController struct {
+	LogDir string
+}
+
+// Show handles GET /v2/jobs/:ID/logs, returning up to `limit` (default
+// defaultJobLogTail) most recent JSON log lines whose "jobID" field matches
+// the job ID in the URL.
+func (jlc *JobLogController) Show(c *gin.Context) {
+	jobID := c.Param("ID")
+
+	limit := defaultJobLogTail
+	if q := c.Query("limit"); q != "" {
+		if n, err := strconv.Atoi(q); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	segments, err := jlc.logSegments()
+	if err != nil || len(segments) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"errors": []string{"log file not available"}})
+		return
+	}
+
+	matches := make([]json.RawMessage, 0, limit)
+	for _, path := range segments {
+		if err := appendMatchingLines(path, jobID, limit, &matches); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"errors": []string{"failed to read log segment"}})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"logs": matches})
+}
+
+// logSegments returns every on-disk log segment under LogDir, oldest first
+// and the live chainlink.log last, so scanning them in order and trimming
+// the running tail yields the most recent matching lines across rotation
+// boundaries. Rotated segments follow lumberjack's naming convention
+// (chainlink-<timestamp>.log[.gz]); compressed ones are transparently
+// gunzipped by appendMatchingLines.
+func (jlc *JobLogController) logSegments() ([]string, error) {
+	rotated, err := filepath.Glob(filepath.Join(jlc.LogDir, "chainlink-*.log*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rotated, func(i, j int) bool {
+		fi, erri := os.Stat(rotated[i])
+		fj, errj := os.Stat(rotated[j])
+		if erri != nil || errj != nil {
+			return rotated[i] < rotated[j]
+		}
+		return fi.ModTime().Before(fj.ModTime())
+	})
+
+	live := filepath.Join(jlc.LogDir, "chainlink.log")
+	if _, err := os.Stat(live); err != nil {
+		return rotated, nil
+	}
+	return append(rotated, live), nil
+}
+
+// appendMatchingLines scans path (gunzipping it first if it has a .gz
+// suffix) for JSON log lines whose "jobID" field matches jobID, appending
+// them to matches and trimming from the front once len(matches) exceeds
+// limit so matches always holds the most recent lines seen so far.
+func appendMatchingLines(path string, jobID string, limit int, matches *[]json.RawMessage) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if filepath.Ext(path) == ".gz" {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		var record struct {
+			JobID string `json:"jobID"`
+		}
+		if err := json.Unmarshal(line, &record); err != nil || record.JobID != jobID {
+			continue
+		}
+
+		entry := make(json.RawMessage, len(line))
+		copy(entry, line)
+		*matches = append(*matches, entry)
+		if len(*matches) > limit {
+			*matches = (*matches)[1:]
+		}
+	}
+	return scanner.Err()
+}
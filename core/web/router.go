@@ -0,0 +1,32 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/job"
+)
+
+// RegisterAdminLogRoutes mounts the per-service log level and per-job log
+// download admin endpoints onto an authenticated route group, e.g. the
+// node's existing `/v2` group:
+//
+//	v2 := r.Group("/v2", authenticationMiddleware...)
+//	web.RegisterAdminLogRoutes(v2, app.GetLogger(), app.GetConfig().LogsDir())
+func RegisterAdminLogRoutes(authenticated *gin.RouterGroup, lggr *logger.Logger, logDir string) {
+	slc := &ServiceLogController{Logger: lggr}
+	authenticated.PUT("/log/:service", slc.Update)
+
+	jlc := &JobLogController{LogDir: logDir}
+	authenticated.GET("/jobs/:ID/logs", jlc.Show)
+}
+
+// RegisterPipelineRunsRoutes mounts the pipeline run listing endpoint onto
+// an authenticated route group:
+//
+//	v2 := r.Group("/v2", authenticationMiddleware...)
+//	web.RegisterPipelineRunsRoutes(v2, app.JobORM())
+func RegisterPipelineRunsRoutes(authenticated *gin.RouterGroup, orm job.ORM) {
+	prc := &PipelineRunsController{ORM: orm}
+	authenticated.GET("/pipeline/runs", prc.Index)
+}
@@ -0,0 +1,48 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+// ServiceLogController exposes runtime control over the log level of a
+// single named service (see logger.GetLogServices), so operators can turn
+// up verbosity on e.g. a stuck FluxMonitor job without restarting the node.
+// It is mounted on the authenticated /v2 route group.
+type ServiceLogController struct {
+	Logger *logger.Logger
+}
+
+// ServiceLogLevelRequest is the body accepted by ServiceLogController.Update.
+type ServiceLogLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// Update handles PUT /v2/log/:service, setting the level of the named
+// service, e.g. PUT /v2/log/fluxmonitor {"level":"debug"}.
+func (slc *ServiceLogController) Update(c *gin.Context) {
+	service := c.Param("service")
+
+	var request ServiceLogLevelRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": []string{err.Error()}})
+		return
+	}
+
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(request.Level)); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"errors": []string{err.Error()}})
+		return
+	}
+
+	if err := slc.Logger.SetServiceLevel(service, lvl); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}